@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// runCLI dispatches a non-interactive subcommand so gorandr can be used
+// from shell scripts and window-manager keybindings without spawning the
+// TUI. main() only calls this when arguments were given.
+func runCLI(args []string) error {
+	switch args[0] {
+	case "daemon":
+		return runDaemon()
+	case "list":
+		return runList(args[1:])
+	case "set":
+		return runSet(args[1:])
+	case "profile":
+		return runProfileCmd(args[1:])
+	default:
+		return fmt.Errorf("unknown command %q (expected list, set, profile, or daemon)", args[0])
+	}
+}
+
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	jsonOut := fs.Bool("json", false, "emit machine-readable JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	displays, err := getDisplays()
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(displays)
+	}
+
+	for _, d := range displays {
+		status := "disconnected"
+		if d.Connected {
+			status = fmt.Sprintf("connected %dx%d @ %.1fHz", d.Current.Width, d.Current.Height, d.Current.Rate)
+		}
+		fmt.Printf("%s: %s\n", d.Name, status)
+		for _, mode := range d.Available {
+			marker := " "
+			if mode.Current {
+				marker = "*"
+			}
+			fmt.Printf("  %s %dx%d @ %.1fHz\n", marker, mode.Width, mode.Height, mode.Rate)
+		}
+	}
+	return nil
+}
+
+func runSet(args []string) error {
+	fs := flag.NewFlagSet("set", flag.ExitOnError)
+	output := fs.String("output", "", "output name, e.g. HDMI-1 (required)")
+	mode := fs.String("mode", "", "resolution, e.g. 2560x1440")
+	rate := fs.Float64("rate", 0, "refresh rate in Hz")
+	pos := fs.String("pos", "", "position, e.g. 1920,0")
+	primary := fs.Bool("primary", false, "make this the primary display")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *output == "" {
+		return fmt.Errorf("--output is required")
+	}
+
+	xrandrArgs := []string{"--output", *output}
+	if *mode != "" {
+		xrandrArgs = append(xrandrArgs, "--mode", *mode)
+	}
+	if *rate != 0 {
+		xrandrArgs = append(xrandrArgs, "--rate", fmt.Sprintf("%.1f", *rate))
+	}
+	if *pos != "" {
+		x, y, err := parsePos(*pos)
+		if err != nil {
+			return err
+		}
+		xrandrArgs = append(xrandrArgs, "--pos", fmt.Sprintf("%d+%d", x, y))
+	}
+	if *primary {
+		xrandrArgs = append(xrandrArgs, "--primary")
+	}
+
+	cmd := exec.Command("xrandr", xrandrArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// parsePos parses a "X,Y" pair, e.g. "1920,0", as used by --pos.
+func parsePos(s string) (x, y int, err error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid --pos %q, expected X,Y", s)
+	}
+	x, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --pos %q: %v", s, err)
+	}
+	y, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --pos %q: %v", s, err)
+	}
+	return x, y, nil
+}
+
+func runProfileCmd(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: gorandr profile <save|load> <name>")
+	}
+	action, name := args[0], args[1]
+
+	switch action {
+	case "save":
+		displays, err := getDisplays()
+		if err != nil {
+			return err
+		}
+		edids, err := getDisplayEDIDs()
+		if err != nil {
+			edids = map[string]string{}
+		}
+		if err := saveProfile(buildProfile(name, displays, edids)); err != nil {
+			return err
+		}
+		fmt.Printf("Saved profile %q\n", name)
+		return nil
+	case "load":
+		profile, err := loadProfile(name)
+		if err != nil {
+			return err
+		}
+		if err := applyProfile(*profile); err != nil {
+			return err
+		}
+		fmt.Printf("Applied profile %q\n", name)
+		return nil
+	default:
+		return fmt.Errorf("unknown profile action %q (expected save or load)", action)
+	}
+}
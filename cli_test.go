@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestParsePos(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantX   int
+		wantY   int
+		wantErr bool
+	}{
+		{"1920,0", 1920, 0, false},
+		{"0,0", 0, 0, false},
+		{"-1920,1080", -1920, 1080, false},
+		{"1920", 0, 0, true},
+		{"1920,0,0", 0, 0, true},
+		{"a,b", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		x, y, err := parsePos(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePos(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (x != tt.wantX || y != tt.wantY) {
+			t.Errorf("parsePos(%q) = (%d, %d), want (%d, %d)", tt.in, x, y, tt.wantX, tt.wantY)
+		}
+	}
+}
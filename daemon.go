@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const daemonPollInterval = 5 * time.Second
+
+// runDaemon polls `xrandr --query`/`xrandr --props` on an interval and,
+// whenever the set of connected displays' EDIDs matches a saved profile's
+// fingerprint, applies that profile. It runs until killed.
+func runDaemon() error {
+	fmt.Println("gorandr daemon: watching for display changes...")
+
+	ticker := time.NewTicker(daemonPollInterval)
+	defer ticker.Stop()
+
+	var lastFingerprint string
+	for range ticker.C {
+		fingerprint, err := currentFingerprint()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gorandr daemon: %v\n", err)
+			continue
+		}
+		if fingerprint == lastFingerprint {
+			continue
+		}
+		lastFingerprint = fingerprint
+
+		if err := applyMatchingProfile(fingerprint); err != nil {
+			fmt.Fprintf(os.Stderr, "gorandr daemon: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+func currentFingerprint() (string, error) {
+	displays, err := getDisplays()
+	if err != nil {
+		return "", err
+	}
+	edids, err := getDisplayEDIDs()
+	if err != nil {
+		return "", err
+	}
+
+	var fingerprints []string
+	for _, d := range displays {
+		if d.Connected {
+			fingerprints = append(fingerprints, edids[d.Name])
+		}
+	}
+	return fingerprintEDIDs(fingerprints), nil
+}
+
+func applyMatchingProfile(fingerprint string) error {
+	names, err := listProfiles()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		p, err := loadProfile(name)
+		if err != nil {
+			continue
+		}
+		if p.Fingerprint != fingerprint {
+			continue
+		}
+
+		fmt.Printf("gorandr daemon: connected displays match profile %q, applying\n", name)
+		if err := applyProfile(*p); err != nil {
+			return fmt.Errorf("failed to apply profile %q: %v", name, err)
+		}
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/grahamhelton/gorandr/internal/fuzzy"
+)
+
+var matchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("213")).Bold(true)
+
+// fuzzyDelegate renders list items, highlighting the runes matched by the
+// active filter so a search like "144" visibly picks out "144Hz" entries.
+type fuzzyDelegate struct{}
+
+func newItemDelegate() fuzzyDelegate { return fuzzyDelegate{} }
+
+func (d fuzzyDelegate) Height() int                               { return 2 }
+func (d fuzzyDelegate) Spacing() int                              { return 1 }
+func (d fuzzyDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
+
+func (d fuzzyDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	it, ok := listItem.(item)
+	if !ok {
+		return
+	}
+
+	title := it.title
+	desc := it.desc
+	if pattern := m.FilterValue(); pattern != "" {
+		title = highlightMatches(pattern, title)
+		desc = highlightMatches(pattern, desc)
+	}
+
+	str := title
+	if desc != "" {
+		str += "\n" + desc
+	}
+
+	if index == m.Index() {
+		lines := strings.Split(str, "\n")
+		for i, line := range lines {
+			lines[i] = selectedItemStyle.Render("> " + line)
+		}
+		fmt.Fprint(w, strings.Join(lines, "\n"))
+		return
+	}
+
+	fmt.Fprint(w, itemStyle.Render(str))
+}
+
+// fuzzyFilter adapts fuzzy.FuzzyMatch to list.FilterFunc, so the matcher
+// that decides highlighting also decides what matches and how it's ranked,
+// rather than leaving filtering to list.DefaultFilter's sahilm/fuzzy.
+func fuzzyFilter(term string, targets []string) []list.Rank {
+	type scoredRank struct {
+		rank  list.Rank
+		score int
+	}
+
+	var scored []scoredRank
+	for i, target := range targets {
+		score, ok := fuzzy.FuzzyMatch(term, target)
+		if !ok {
+			continue
+		}
+		scored = append(scored, scoredRank{
+			rank:  list.Rank{Index: i, MatchedIndexes: fuzzy.MatchedIndices(term, target)},
+			score: score,
+		})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	ranks := make([]list.Rank, len(scored))
+	for i, s := range scored {
+		ranks[i] = s.rank
+	}
+	return ranks
+}
+
+// highlightMatches wraps the runes of candidate matched by pattern in
+// matchStyle, leaving the rest untouched.
+func highlightMatches(pattern, candidate string) string {
+	indices := fuzzy.MatchedIndices(pattern, candidate)
+	if len(indices) == 0 {
+		return candidate
+	}
+
+	matched := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		matched[i] = true
+	}
+
+	var b strings.Builder
+	for i, r := range []rune(candidate) {
+		if matched[i] {
+			b.WriteString(matchStyle.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -8,8 +8,10 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -29,10 +31,36 @@ var (
 )
 
 type Display struct {
-	Name      string
-	Current   Mode
-	Available []Mode
-	Connected bool
+	Name          string
+	Current       Mode
+	Available     []Mode
+	Connected     bool
+	PosX          int
+	PosY          int
+	Primary       bool
+	RelativeTo    string
+	Rotation      string  // "normal", "left", "right", or "inverted"
+	Reflection    string  // "none", "x", "y", or "xy"
+	Scale         float64 // 1.0 means no scaling
+	FilterNearest bool    // scaling filter: nearest if true, bilinear (xrandr's default) if false
+}
+
+// transform returns d's rotation/reflection/scale as a transform, filling
+// in the identity defaults for a display that reported none.
+func (d Display) transform() transform {
+	rotation := d.Rotation
+	if rotation == "" {
+		rotation = "normal"
+	}
+	reflection := d.Reflection
+	if reflection == "" {
+		reflection = "none"
+	}
+	scale := d.Scale
+	if scale == 0 {
+		scale = 1.0
+	}
+	return transform{Rotation: rotation, Reflection: reflection, Scale: scale, FilterNearest: d.FilterNearest}
 }
 
 type Mode struct {
@@ -83,13 +111,33 @@ func (i item) Description() string { return i.desc }
 type state int
 
 const (
-	selectingDisplay state = iota
+	mainMenu state = iota
+	selectingDisplay
 	selectingResolution
+	enteringCustomMode
 	selectingRefreshRate
+	selectingTransform
+	selectingLayout
+	namingProfile
+	selectingProfileToLoad
+	selectingProfileToDelete
 	applying
+	confirming
 	done
 )
 
+// confirmTimeout is how long a newly applied mode is shown before it is
+// automatically reverted if the user doesn't confirm it, mirroring how
+// GNOME/KDE handle risky resolution changes.
+const confirmTimeout = 15
+
+// layoutOption pairs a relative-position xrandr flag with the display it is
+// anchored to, e.g. {flag: "left-of", target: "HDMI-1"}.
+type layoutOption struct {
+	flag   string
+	target string
+}
+
 type model struct {
 	state        state
 	displays     []Display
@@ -99,6 +147,62 @@ type model struct {
 	list         list.Model
 	message      string
 	err          error
+
+	// Layout wizard step (selectingLayout), only reached when more than one
+	// display is connected. Phase 0 picks a position relative to another
+	// display, phase 1 asks whether the display being configured should
+	// become primary.
+	pendingMode    Mode
+	layoutPhase    int
+	layoutOptions  []layoutOption
+	layoutRelation string
+	layoutTarget   string
+	layoutPrimary  bool
+
+	// Profile subsystem state (mainMenu, namingProfile,
+	// selectingProfileToLoad, selectingProfileToDelete).
+	edids            map[string]string
+	profileNameInput string
+	profileNames     []string
+
+	// Confirmation/revert state (confirming). previousDisplay is the full
+	// display configuration captured right before applyMode ran, so it can
+	// be reapplied verbatim if the countdown expires.
+	previousDisplay Display
+	countdown       int
+	reverting       bool
+
+	// Custom modeline creation (enteringCustomMode).
+	customInputs []textinput.Model
+	customFocus  int
+
+	// Rotation/reflection/scale wizard step (selectingTransform).
+	transformPhase         int
+	transformRotation      string
+	transformReflection    string
+	transformScale         float64
+	transformFilterNearest bool
+	transformScaleInput    textinput.Model
+}
+
+// currentTransform bundles the rotation/reflection/scale choices made in
+// the selectingTransform step for applyMode.
+func (m model) currentTransform() transform {
+	return transform{
+		Rotation:      m.transformRotation,
+		Reflection:    m.transformReflection,
+		Scale:         m.transformScale,
+		FilterNearest: m.transformFilterNearest,
+	}
+}
+
+// tickMsg drives the confirming countdown, one message per second.
+type tickMsg struct{}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
 }
 
 func initialModel() model {
@@ -106,44 +210,166 @@ func initialModel() model {
 	if err != nil {
 		return model{err: err}
 	}
-
-	items := make([]list.Item, len(displays))
-	for i, display := range displays {
-		status := "disconnected"
-		if display.Connected {
-			status = fmt.Sprintf("connected - %dx%d @ %.1fHz",
-				display.Current.Width, display.Current.Height, display.Current.Rate)
-		}
-		items[i] = item{
-			title: display.Name,
-			desc:  status,
-		}
+	edids, err := getDisplayEDIDs()
+	if err != nil {
+		edids = map[string]string{}
 	}
 
-	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
-	l.Title = "Select Display"
+	l := list.New(nil, newItemDelegate(), 0, 0)
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	l.SetFilteringEnabled(true)
+	l.Filter = fuzzyFilter
 
-	return model{
-		state:    selectingDisplay,
+	m := model{
+		state:    mainMenu,
 		displays: displays,
+		edids:    edids,
 		list:     l,
 	}
+	m.setupMainMenuList()
+	return m
 }
 
 func (m model) Init() tea.Cmd {
 	return nil
 }
 
+// connectedCount returns how many displays are currently connected.
+func (m model) connectedCount() int {
+	count := 0
+	for _, d := range m.displays {
+		if d.Connected {
+			count++
+		}
+	}
+	return count
+}
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch m.state {
+		case mainMenu:
+			switch msg.String() {
+			case "ctrl+c", "q":
+				return m, tea.Quit
+			case "enter":
+				switch m.list.Index() {
+				case 0: // Configure Displays
+					m.state = selectingDisplay
+					m.setupDisplayList()
+				case 1: // Save current as profile…
+					m.profileNameInput = ""
+					m.state = namingProfile
+				case 2: // Load profile
+					names, err := listProfiles()
+					if err != nil {
+						m.message = fmt.Sprintf("Error: %v", err)
+						return m, nil
+					}
+					m.profileNames = names
+					m.setupProfileList("Load Profile")
+					m.state = selectingProfileToLoad
+				case 3: // Delete profile
+					names, err := listProfiles()
+					if err != nil {
+						m.message = fmt.Sprintf("Error: %v", err)
+						return m, nil
+					}
+					m.profileNames = names
+					m.setupProfileList("Delete Profile")
+					m.state = selectingProfileToDelete
+				}
+				return m, nil
+			}
+		case namingProfile:
+			switch msg.Type {
+			case tea.KeyCtrlC:
+				return m, tea.Quit
+			case tea.KeyEsc:
+				m.state = mainMenu
+				m.setupMainMenuList()
+				return m, nil
+			case tea.KeyEnter:
+				name := strings.TrimSpace(m.profileNameInput)
+				if name == "" {
+					return m, nil
+				}
+				profile := buildProfile(name, m.displays, m.edids)
+				err := saveProfile(profile)
+				m.state = done
+				if err != nil {
+					m.message = fmt.Sprintf("Error: %v", err)
+				} else {
+					m.message = fmt.Sprintf("✓ Saved profile %q", name)
+				}
+				return m, nil
+			case tea.KeyBackspace:
+				if len(m.profileNameInput) > 0 {
+					m.profileNameInput = m.profileNameInput[:len(m.profileNameInput)-1]
+				}
+				return m, nil
+			case tea.KeyRunes, tea.KeySpace:
+				m.profileNameInput += msg.String()
+				return m, nil
+			}
+			return m, nil
+		case selectingProfileToLoad:
+			switch msg.String() {
+			case "ctrl+c", "q":
+				return m, tea.Quit
+			case "esc":
+				m.state = mainMenu
+				m.setupMainMenuList()
+				return m, nil
+			case "enter":
+				if len(m.profileNames) == 0 {
+					return m, nil
+				}
+				name := m.profileNames[m.list.Index()]
+				profile, err := loadProfile(name)
+				m.state = done
+				if err != nil {
+					m.message = fmt.Sprintf("Error: %v", err)
+					return m, nil
+				}
+				if err := applyProfile(*profile); err != nil {
+					m.message = fmt.Sprintf("Error: %v", err)
+				} else {
+					m.message = fmt.Sprintf("✓ Applied profile %q", name)
+				}
+				return m, nil
+			}
+		case selectingProfileToDelete:
+			switch msg.String() {
+			case "ctrl+c", "q":
+				return m, tea.Quit
+			case "esc":
+				m.state = mainMenu
+				m.setupMainMenuList()
+				return m, nil
+			case "enter":
+				if len(m.profileNames) == 0 {
+					return m, nil
+				}
+				name := m.profileNames[m.list.Index()]
+				err := deleteProfile(name)
+				m.state = done
+				if err != nil {
+					m.message = fmt.Sprintf("Error: %v", err)
+				} else {
+					m.message = fmt.Sprintf("✓ Deleted profile %q", name)
+				}
+				return m, nil
+			}
 		case selectingDisplay:
 			switch msg.String() {
 			case "ctrl+c", "q":
 				return m, tea.Quit
+			case "esc":
+				m.state = mainMenu
+				m.setupMainMenuList()
+				return m, nil
 			case "enter":
 				if len(m.displays) == 0 {
 					return m, tea.Quit
@@ -167,11 +393,51 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.setupDisplayList()
 				return m, nil
 			case "enter":
-				m.selectedRes = m.list.Index()
+				idx := m.list.Index()
+				if idx == len(m.resolutions) {
+					m.state = enteringCustomMode
+					m.setupCustomModeInputs()
+					return m, nil
+				}
+				m.selectedRes = idx
 				m.state = selectingRefreshRate
 				m.setupRefreshRateList()
 				return m, nil
 			}
+		case enteringCustomMode:
+			switch msg.Type {
+			case tea.KeyCtrlC:
+				return m, tea.Quit
+			case tea.KeyEsc:
+				m.state = selectingResolution
+				m.setupResolutionList()
+				return m, nil
+			case tea.KeyTab, tea.KeyDown:
+				m.customInputs[m.customFocus].Blur()
+				m.customFocus = (m.customFocus + 1) % len(m.customInputs)
+				m.customInputs[m.customFocus].Focus()
+				return m, nil
+			case tea.KeyShiftTab, tea.KeyUp:
+				m.customInputs[m.customFocus].Blur()
+				m.customFocus = (m.customFocus - 1 + len(m.customInputs)) % len(m.customInputs)
+				m.customInputs[m.customFocus].Focus()
+				return m, nil
+			case tea.KeyEnter:
+				width, werr := strconv.Atoi(strings.TrimSpace(m.customInputs[0].Value()))
+				height, herr := strconv.Atoi(strings.TrimSpace(m.customInputs[1].Value()))
+				rate, rerr := strconv.ParseFloat(strings.TrimSpace(m.customInputs[2].Value()), 64)
+				if werr != nil || herr != nil || rerr != nil || width <= 0 || height <= 0 || rate <= 0 {
+					m.message = "Enter a valid width, height, and refresh rate"
+					return m, nil
+				}
+				display := m.displays[m.selectedDisp]
+				m.state = applying
+				m.message = "Creating custom mode..."
+				return m, m.createCustomMode(display.Name, width, height, rate)
+			}
+			var cmd tea.Cmd
+			m.customInputs[m.customFocus], cmd = m.customInputs[m.customFocus].Update(msg)
+			return m, cmd
 		case selectingRefreshRate:
 			switch msg.String() {
 			case "ctrl+c", "q":
@@ -182,13 +448,123 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case "enter":
 				rateIdx := m.list.Index()
-				display := m.displays[m.selectedDisp]
 				resolution := m.resolutions[m.selectedRes]
-				mode := resolution.Modes[rateIdx]
+				m.pendingMode = resolution.Modes[rateIdx]
+				m.state = selectingTransform
+				m.setupTransformRotationList()
+				return m, nil
+			}
+		case selectingTransform:
+			switch m.transformPhase {
+			case 0:
+				switch msg.String() {
+				case "ctrl+c", "q":
+					return m, tea.Quit
+				case "esc":
+					m.state = selectingRefreshRate
+					m.setupRefreshRateList()
+					return m, nil
+				case "enter":
+					m.transformRotation = rotationValues[m.list.Index()]
+					m.setupTransformReflectionList()
+					return m, nil
+				}
+			case 1:
+				switch msg.String() {
+				case "ctrl+c", "q":
+					return m, tea.Quit
+				case "esc":
+					m.setupTransformRotationList()
+					return m, nil
+				case "enter":
+					m.transformReflection = reflectionValues[m.list.Index()]
+					m.setupTransformScaleInput()
+					return m, nil
+				}
+			case 2:
+				switch msg.Type {
+				case tea.KeyCtrlC:
+					return m, tea.Quit
+				case tea.KeyEsc:
+					m.setupTransformReflectionList()
+					return m, nil
+				case tea.KeyTab:
+					m.transformFilterNearest = !m.transformFilterNearest
+					return m, nil
+				case tea.KeyEnter:
+					m.transformScale = 1.0
+					if v := strings.TrimSpace(m.transformScaleInput.Value()); v != "" {
+						parsed, err := strconv.ParseFloat(v, 64)
+						if err != nil || parsed <= 0 {
+							m.message = "Enter a valid scale factor"
+							return m, nil
+						}
+						m.transformScale = parsed
+					}
+
+					if m.connectedCount() > 1 {
+						m.state = selectingLayout
+						m.setupLayoutList()
+						return m, nil
+					}
 
+					display := m.displays[m.selectedDisp]
+					m.previousDisplay = display
+					m.state = applying
+					m.message = "Applying changes..."
+					return m, m.applyMode(display.Name, m.pendingMode, "", "", display.Primary, m.currentTransform())
+				}
+				var cmd tea.Cmd
+				m.transformScaleInput, cmd = m.transformScaleInput.Update(msg)
+				return m, cmd
+			}
+		case selectingLayout:
+			switch msg.String() {
+			case "ctrl+c", "q":
+				return m, tea.Quit
+			case "esc":
+				if m.layoutPhase == 1 {
+					m.setupLayoutList()
+					return m, nil
+				}
+				m.state = selectingTransform
+				m.setupTransformScaleInput()
+				return m, nil
+			case "enter":
+				if m.layoutPhase == 0 {
+					idx := m.list.Index()
+					if idx == 0 {
+						m.layoutRelation = ""
+						m.layoutTarget = ""
+					} else {
+						opt := m.layoutOptions[idx-1]
+						m.layoutRelation = opt.flag
+						m.layoutTarget = opt.target
+					}
+					m.setupLayoutPrimaryList()
+					return m, nil
+				}
+
+				m.layoutPrimary = m.list.Index() == 1
+				display := m.displays[m.selectedDisp]
+				m.previousDisplay = display
 				m.state = applying
 				m.message = "Applying changes..."
-				return m, m.applyMode(display.Name, mode)
+				return m, m.applyMode(display.Name, m.pendingMode, m.layoutRelation, m.layoutTarget, m.layoutPrimary, m.currentTransform())
+			}
+		case confirming:
+			switch msg.String() {
+			case "ctrl+c", "q":
+				return m, tea.Quit
+			case "y", "Y", "enter":
+				m.state = done
+				m.message = "✓ Display settings applied successfully!"
+				return m, nil
+			case "n", "N":
+				m.reverting = true
+				m.state = applying
+				m.message = "Reverting to previous settings..."
+				return m, m.applyMode(m.previousDisplay.Name, m.previousDisplay.Current, "", "", m.previousDisplay.Primary, m.previousDisplay.transform())
 			}
 		case applying, done:
 			switch msg.String() {
@@ -202,14 +578,64 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list.SetHeight(msg.Height - 3)
 		return m, nil
 
-	case applyMsg:
-		m.state = done
+	case customModeMsg:
 		if msg.err != nil {
+			m.state = done
 			m.message = fmt.Sprintf("Error: %v", msg.err)
-		} else {
-			m.message = "✓ Display settings applied successfully!"
+			return m, nil
+		}
+		displays, err := getDisplays()
+		if err != nil {
+			m.state = done
+			m.message = fmt.Sprintf("Error refreshing displays: %v", err)
+			return m, nil
+		}
+		m.displays = displays
+		m.state = selectingResolution
+		m.setupResolutionList()
+		if w, h, ok := resolutionFromModeName(msg.name); ok {
+			for i, r := range m.resolutions {
+				if r.Width == w && r.Height == h {
+					m.selectedRes = i
+					m.list.Select(i)
+					break
+				}
+			}
 		}
 		return m, nil
+
+	case applyMsg:
+		if m.reverting {
+			m.reverting = false
+			m.state = done
+			if msg.err != nil {
+				m.message = fmt.Sprintf("Error reverting settings: %v", msg.err)
+			} else {
+				m.message = "Reverted to previous settings."
+			}
+			return m, nil
+		}
+		if msg.err != nil {
+			m.state = done
+			m.message = fmt.Sprintf("Error: %v", msg.err)
+			return m, nil
+		}
+		m.state = confirming
+		m.countdown = confirmTimeout
+		return m, tickCmd()
+
+	case tickMsg:
+		if m.state != confirming {
+			return m, nil
+		}
+		m.countdown--
+		if m.countdown <= 0 {
+			m.reverting = true
+			m.state = applying
+			m.message = "Reverting to previous settings..."
+			return m, m.applyMode(m.previousDisplay.Name, m.previousDisplay.Current, "", "", m.previousDisplay.Primary, m.previousDisplay.transform())
+		}
+		return m, tickCmd()
 	}
 
 	var cmd tea.Cmd
@@ -217,6 +643,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m *model) setupMainMenuList() {
+	items := []list.Item{
+		item{title: "Configure Displays", desc: "Change resolution, refresh rate, and layout"},
+		item{title: "Save Current as Profile…", desc: "Save the active display configuration"},
+		item{title: "Load Profile", desc: "Apply a previously saved display configuration"},
+		item{title: "Delete Profile", desc: "Remove a saved display configuration"},
+	}
+	m.list.SetItems(items)
+	m.list.ResetFilter()
+	m.list.Title = "gorandr"
+}
+
+func (m *model) setupProfileList(title string) {
+	items := make([]list.Item, len(m.profileNames))
+	for i, name := range m.profileNames {
+		items[i] = item{title: name}
+	}
+	m.list.SetItems(items)
+	m.list.ResetFilter()
+	m.list.Title = title
+}
+
 func (m *model) setupDisplayList() {
 	items := make([]list.Item, len(m.displays))
 	for i, display := range m.displays {
@@ -224,6 +672,9 @@ func (m *model) setupDisplayList() {
 		if display.Connected {
 			status = fmt.Sprintf("connected - %dx%d @ %.1fHz",
 				display.Current.Width, display.Current.Height, display.Current.Rate)
+			if display.RelativeTo != "" {
+				status += ", " + display.RelativeTo
+			}
 		}
 		items[i] = item{
 			title: display.Name,
@@ -231,6 +682,7 @@ func (m *model) setupDisplayList() {
 		}
 	}
 	m.list.SetItems(items)
+	m.list.ResetFilter()
 	m.list.Title = "Select Display"
 }
 
@@ -282,10 +734,35 @@ func (m *model) setupResolutionList() {
 			desc:  desc,
 		}
 	}
+	items = append(items, item{
+		title: "+ Create custom mode…",
+		desc:  "Run cvt and xrandr --newmode to define a new resolution",
+	})
 	m.list.SetItems(items)
+	m.list.ResetFilter()
 	m.list.Title = fmt.Sprintf("Select Resolution for %s", display.Name)
 }
 
+// setupCustomModeInputs resets the width/height/refresh-rate text inputs
+// used by enteringCustomMode, focusing the first one.
+func (m *model) setupCustomModeInputs() {
+	labels := []string{"Width: ", "Height: ", "Refresh Rate: "}
+	placeholders := []string{"1920", "1080", "60"}
+
+	m.customInputs = make([]textinput.Model, len(labels))
+	for i, label := range labels {
+		ti := textinput.New()
+		ti.Prompt = label
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = 8
+		if i == 0 {
+			ti.Focus()
+		}
+		m.customInputs[i] = ti
+	}
+	m.customFocus = 0
+}
+
 func (m *model) setupRefreshRateList() {
 	resolution := m.resolutions[m.selectedRes]
 	items := make([]list.Item, len(resolution.Modes))
@@ -303,24 +780,276 @@ func (m *model) setupRefreshRateList() {
 	}
 
 	m.list.SetItems(items)
+	m.list.ResetFilter()
 	m.list.Title = fmt.Sprintf("Select Refresh Rate for %dx%d",
 		resolution.Width, resolution.Height)
 }
 
+// setupLayoutList builds the phase-0 layout menu: a "keep current position"
+// default plus one entry per (relation, other connected display) pair.
+func (m *model) setupLayoutList() {
+	display := m.displays[m.selectedDisp]
+
+	items := []list.Item{
+		item{title: "Keep current position", desc: "Do not change placement relative to other displays"},
+	}
+	m.layoutOptions = nil
+
+	relations := []struct{ flag, label string }{
+		{"left-of", "Left of"},
+		{"right-of", "Right of"},
+		{"above", "Above"},
+		{"below", "Below"},
+		{"same-as", "Same position as"},
+	}
+
+	for i, other := range m.displays {
+		if i == m.selectedDisp || !other.Connected {
+			continue
+		}
+		for _, rel := range relations {
+			items = append(items, item{
+				title: fmt.Sprintf("%s %s", rel.label, other.Name),
+				desc:  fmt.Sprintf("xrandr --%s %s", rel.flag, other.Name),
+			})
+			m.layoutOptions = append(m.layoutOptions, layoutOption{flag: rel.flag, target: other.Name})
+		}
+	}
+
+	m.list.SetItems(items)
+	m.list.ResetFilter()
+	m.list.Title = fmt.Sprintf("Arrange %s", display.Name)
+	m.layoutPhase = 0
+}
+
+// setupLayoutPrimaryList builds the phase-1 layout menu asking whether the
+// display being configured should become the primary display.
+func (m *model) setupLayoutPrimaryList() {
+	display := m.displays[m.selectedDisp]
+	items := []list.Item{
+		item{title: "No", desc: "Keep the current primary display"},
+		item{title: "Yes", desc: fmt.Sprintf("Make %s the primary display", display.Name)},
+	}
+	m.list.SetItems(items)
+	m.list.ResetFilter()
+	m.list.Title = fmt.Sprintf("Make %s primary?", display.Name)
+	m.layoutPhase = 1
+}
+
+var rotationValues = []string{"normal", "left", "right", "inverted"}
+
+// setupTransformRotationList builds phase-0 of the transform step: choosing
+// a rotation for the display being configured.
+func (m *model) setupTransformRotationList() {
+	display := m.displays[m.selectedDisp]
+	current := display.Rotation
+	if current == "" {
+		current = "normal"
+	}
+
+	items := make([]list.Item, len(rotationValues))
+	for i, v := range rotationValues {
+		desc := fmt.Sprintf("xrandr --rotate %s", v)
+		if v == current {
+			desc += " (current)"
+		}
+		items[i] = item{title: v, desc: desc}
+	}
+	m.list.SetItems(items)
+	m.list.ResetFilter()
+	m.list.Title = fmt.Sprintf("Rotation for %s", display.Name)
+	m.transformPhase = 0
+}
+
+var reflectionValues = []string{"none", "x", "y", "xy"}
+
+// setupTransformReflectionList builds phase-1 of the transform step:
+// choosing a reflection axis for the display being configured.
+func (m *model) setupTransformReflectionList() {
+	display := m.displays[m.selectedDisp]
+	current := display.Reflection
+	if current == "" {
+		current = "none"
+	}
+
+	items := make([]list.Item, len(reflectionValues))
+	for i, v := range reflectionValues {
+		desc := fmt.Sprintf("xrandr --reflect %s", v)
+		if v == current {
+			desc += " (current)"
+		}
+		items[i] = item{title: v, desc: desc}
+	}
+	m.list.SetItems(items)
+	m.list.ResetFilter()
+	m.list.Title = fmt.Sprintf("Reflection for %s", display.Name)
+	m.transformPhase = 1
+}
+
+// setupTransformScaleInput builds phase-2 of the transform step: a text
+// input for a fractional scale factor, e.g. 1.25 becomes --scale 0.8x0.8.
+func (m *model) setupTransformScaleInput() {
+	display := m.displays[m.selectedDisp]
+
+	ti := textinput.New()
+	ti.Prompt = "Scale factor: "
+	ti.Placeholder = "1.0"
+	ti.CharLimit = 8
+	if display.Scale != 0 {
+		ti.SetValue(fmt.Sprintf("%.2f", display.Scale))
+	}
+	ti.Focus()
+
+	m.transformScaleInput = ti
+	m.transformPhase = 2
+}
+
 type applyMsg struct {
 	err error
 }
 
-func (m model) applyMode(display string, mode Mode) tea.Cmd {
+type customModeMsg struct {
+	name string
+	err  error
+}
+
+// createCustomMode shells out to cvt to compute a modeline for the given
+// width/height/refresh rate, registers it with xrandr --newmode, and adds
+// it to output. If the mode already exists, xrandr --newmode fails with
+// "BadName" and is skipped so the mode can still be added to the output.
+func (m model) createCustomMode(output string, width, height int, rate float64) tea.Cmd {
+	return func() tea.Msg {
+		cvtOut, err := exec.Command("cvt", strconv.Itoa(width), strconv.Itoa(height), fmt.Sprintf("%.2f", rate)).Output()
+		if err != nil {
+			return customModeMsg{err: fmt.Errorf("failed to run cvt: %v", err)}
+		}
+
+		name, params, err := parseModeline(string(cvtOut))
+		if err != nil {
+			return customModeMsg{err: err}
+		}
+
+		newModeArgs := append([]string{"--newmode", name}, params...)
+		if out, err := exec.Command("xrandr", newModeArgs...).CombinedOutput(); err != nil && !strings.Contains(string(out), "BadName") {
+			return customModeMsg{err: fmt.Errorf("failed to create mode %q: %v", name, err)}
+		}
+
+		if err := exec.Command("xrandr", "--addmode", output, name).Run(); err != nil {
+			return customModeMsg{err: fmt.Errorf("failed to add mode %q to %s: %v", name, output, err)}
+		}
+
+		return customModeMsg{name: name}
+	}
+}
+
+var modelineRe = regexp.MustCompile(`Modeline\s+"([^"]+)"\s+(.+)`)
+
+// parseModeline extracts the mode name and xrandr --newmode parameters from
+// a line of `cvt` output such as:
+//
+//	Modeline "1920x1080_60.00"  173.00  1920 2048 2248 2576  1080 1083 1088 1120 -hsync +vsync
+func parseModeline(output string) (name string, params []string, err error) {
+	for _, line := range strings.Split(output, "\n") {
+		if matches := modelineRe.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			return matches[1], strings.Fields(matches[2]), nil
+		}
+	}
+	return "", nil, fmt.Errorf("no Modeline found in cvt output")
+}
+
+var modeNameResolutionRe = regexp.MustCompile(`^(\d+)x(\d+)`)
+
+// resolutionFromModeName extracts the leading WxH from a cvt-generated mode
+// name like "1920x1080_60.00" so the new mode can be preselected.
+func resolutionFromModeName(name string) (width, height int, ok bool) {
+	matches := modeNameResolutionRe.FindStringSubmatch(name)
+	if matches == nil {
+		return 0, 0, false
+	}
+	width, _ = strconv.Atoi(matches[1])
+	height, _ = strconv.Atoi(matches[2])
+	return width, height, true
+}
+
+// applyMode composes a single xrandr invocation covering every connected
+// display so the whole layout is reconfigured atomically. target receives
+// the newly selected mode and, if given, a position relative to relTarget;
+// every other connected display is re-asserted with its existing mode,
+// position, and primary flag so it isn't disturbed.
+func (m model) applyMode(target string, mode Mode, relation, relTarget string, primary bool, tr transform) tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command("xrandr", "--output", display, "--mode",
-			fmt.Sprintf("%dx%d", mode.Width, mode.Height), "--rate",
-			fmt.Sprintf("%.1f", mode.Rate))
+		var args []string
+
+		for _, d := range m.displays {
+			if !d.Connected {
+				continue
+			}
+
+			args = append(args, "--output", d.Name)
+
+			if d.Name == target {
+				args = append(args, "--mode", fmt.Sprintf("%dx%d", mode.Width, mode.Height))
+				args = append(args, "--rate", fmt.Sprintf("%.1f", mode.Rate))
+				switch {
+				case relation != "":
+					args = append(args, "--"+relation, relTarget)
+				case d.PosX != 0 || d.PosY != 0:
+					args = append(args, "--pos", fmt.Sprintf("%d+%d", d.PosX, d.PosY))
+				}
+				if primary {
+					args = append(args, "--primary")
+				}
+				args = append(args, tr.args()...)
+				continue
+			}
+
+			args = append(args, "--mode", fmt.Sprintf("%dx%d", d.Current.Width, d.Current.Height))
+			args = append(args, "--rate", fmt.Sprintf("%.1f", d.Current.Rate))
+			if d.PosX != 0 || d.PosY != 0 {
+				args = append(args, "--pos", fmt.Sprintf("%d+%d", d.PosX, d.PosY))
+			}
+			if d.Primary && !primary {
+				args = append(args, "--primary")
+			}
+			args = append(args, d.transform().args()...)
+		}
+
+		cmd := exec.Command("xrandr", args...)
 		err := cmd.Run()
 		return applyMsg{err: err}
 	}
 }
 
+// transform is the rotation/reflection/scale chosen for a display in the
+// selectingTransform wizard step (or captured from its current state when
+// re-asserting an untouched display).
+type transform struct {
+	Rotation      string
+	Reflection    string
+	Scale         float64
+	FilterNearest bool
+}
+
+// args renders t as the xrandr flags needed to apply it, omitting anything
+// already at its identity value (normal rotation, no reflection, 1:1 scale).
+func (t transform) args() []string {
+	var args []string
+	if t.Rotation != "" && t.Rotation != "normal" {
+		args = append(args, "--rotate", t.Rotation)
+	}
+	if t.Reflection != "" && t.Reflection != "none" {
+		args = append(args, "--reflect", t.Reflection)
+	}
+	if t.Scale != 0 && t.Scale != 1.0 {
+		factor := 1 / t.Scale
+		args = append(args, "--scale", fmt.Sprintf("%.4gx%.4g", factor, factor))
+		if t.FilterNearest {
+			args = append(args, "--filter", "nearest")
+		}
+	}
+	return args
+}
+
 func (m model) View() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error: %v\n\nPress any key to exit.", m.err)
@@ -329,16 +1058,58 @@ func (m model) View() string {
 	var content strings.Builder
 
 	switch m.state {
-	case selectingDisplay, selectingResolution, selectingRefreshRate:
+	case mainMenu, selectingDisplay, selectingResolution, selectingRefreshRate, selectingLayout,
+		selectingProfileToLoad, selectingProfileToDelete:
 		content.WriteString(m.list.View())
-		if m.state == selectingResolution || m.state == selectingRefreshRate {
+		if m.state != mainMenu {
+			content.WriteString("\n")
+			content.WriteString(statusStyle.Render("Press 'esc' to go back"))
+		}
+		if m.state == selectingLayout {
+			content.WriteString("\n\n")
+			content.WriteString(renderLayoutPreview(m.displays))
+		}
+	case selectingTransform:
+		if m.transformPhase < 2 {
+			content.WriteString(m.list.View())
 			content.WriteString("\n")
 			content.WriteString(statusStyle.Render("Press 'esc' to go back"))
+		} else {
+			display := m.displays[m.selectedDisp]
+			content.WriteString(titleStyle.Render(fmt.Sprintf("Scale for %s", display.Name)))
+			content.WriteString("\n\n")
+			content.WriteString(itemStyle.Render(m.transformScaleInput.View()))
+			content.WriteString("\n\n")
+			filterLabel := "bilinear (default)"
+			if m.transformFilterNearest {
+				filterLabel = "nearest"
+			}
+			content.WriteString(statusStyle.Render(fmt.Sprintf(
+				"Filter: %s (tab to toggle) — enter to continue, esc to go back", filterLabel)))
+		}
+	case namingProfile:
+		content.WriteString(titleStyle.Render("Save Current as Profile"))
+		content.WriteString("\n\n")
+		content.WriteString(itemStyle.Render(fmt.Sprintf("Name: %s_", m.profileNameInput)))
+		content.WriteString("\n\n")
+		content.WriteString(statusStyle.Render("Press enter to save, esc to cancel"))
+	case enteringCustomMode:
+		content.WriteString(titleStyle.Render("Create Custom Mode"))
+		content.WriteString("\n\n")
+		for _, ti := range m.customInputs {
+			content.WriteString(itemStyle.Render(ti.View()))
+			content.WriteString("\n")
 		}
+		content.WriteString("\n")
+		content.WriteString(statusStyle.Render("Tab to switch fields, enter to create, esc to cancel"))
 	case applying:
 		content.WriteString(titleStyle.Render("Applying Changes..."))
 		content.WriteString("\n\n")
 		content.WriteString(itemStyle.Render("Please wait..."))
+	case confirming:
+		content.WriteString(titleStyle.Render("Confirm Changes"))
+		content.WriteString("\n\n")
+		content.WriteString(itemStyle.Render(fmt.Sprintf("Keep these settings? (y/N) — reverting in %ds", m.countdown)))
 	case done:
 		content.WriteString(titleStyle.Render("Done!"))
 		content.WriteString("\n\n")
@@ -347,7 +1118,7 @@ func (m model) View() string {
 		content.WriteString(statusStyle.Render("Press any key to exit"))
 	}
 
-	if m.message != "" && m.state == selectingDisplay {
+	if m.message != "" && (m.state == selectingDisplay || m.state == mainMenu) {
 		content.WriteString("\n")
 		content.WriteString(statusStyle.Render(m.message))
 	}
@@ -355,6 +1126,120 @@ func (m model) View() string {
 	return content.String()
 }
 
+// renderLayoutPreview draws a small ASCII grid showing every connected
+// display as a rectangle scaled to its resolution and positioned according
+// to its current PosX/PosY, similar to arandr's layout view.
+func renderLayoutPreview(displays []Display) string {
+	var connected []Display
+	for _, d := range displays {
+		if d.Connected {
+			connected = append(connected, d)
+		}
+	}
+	if len(connected) == 0 {
+		return ""
+	}
+
+	minX, minY := connected[0].PosX, connected[0].PosY
+	maxX, maxY := connected[0].PosX+connected[0].Current.Width, connected[0].PosY+connected[0].Current.Height
+	for _, d := range connected[1:] {
+		if d.PosX < minX {
+			minX = d.PosX
+		}
+		if d.PosY < minY {
+			minY = d.PosY
+		}
+		if d.PosX+d.Current.Width > maxX {
+			maxX = d.PosX + d.Current.Width
+		}
+		if d.PosY+d.Current.Height > maxY {
+			maxY = d.PosY + d.Current.Height
+		}
+	}
+
+	const gridWidth = 50.0
+	totalWidth := float64(maxX - minX)
+	if totalWidth <= 0 {
+		totalWidth = 1
+	}
+	scale := gridWidth / totalWidth
+
+	canvasW := int(totalWidth*scale) + 2
+	canvasH := int(float64(maxY-minY)*scale) + 2
+	if canvasH < 3 {
+		canvasH = 3
+	}
+
+	canvas := make([][]rune, canvasH)
+	for i := range canvas {
+		canvas[i] = make([]rune, canvasW)
+		for j := range canvas[i] {
+			canvas[i][j] = ' '
+		}
+	}
+
+	for _, d := range connected {
+		x0 := int(float64(d.PosX-minX) * scale)
+		y0 := int(float64(d.PosY-minY) * scale)
+		w := int(float64(d.Current.Width) * scale)
+		h := int(float64(d.Current.Height) * scale)
+		if w < 3 {
+			w = 3
+		}
+		if h < 3 {
+			h = 3
+		}
+		label := d.Name
+		if d.Primary {
+			label += " (primary)"
+		}
+		drawBox(canvas, x0, y0, w, h, label)
+	}
+
+	var b strings.Builder
+	for _, row := range canvas {
+		b.WriteString(strings.TrimRight(string(row), " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// drawBox draws a rectangle border with a truncated label on the second row,
+// clipping anything outside the canvas bounds.
+func drawBox(canvas [][]rune, x, y, w, h int, label string) {
+	height := len(canvas)
+	if height == 0 {
+		return
+	}
+	width := len(canvas[0])
+
+	set := func(r, c int, ch rune) {
+		if r >= 0 && r < height && c >= 0 && c < width {
+			canvas[r][c] = ch
+		}
+	}
+
+	for c := x; c < x+w; c++ {
+		set(y, c, '-')
+		set(y+h-1, c, '-')
+	}
+	for r := y; r < y+h; r++ {
+		set(r, x, '|')
+		set(r, x+w-1, '|')
+	}
+	set(y, x, '+')
+	set(y, x+w-1, '+')
+	set(y+h-1, x, '+')
+	set(y+h-1, x+w-1, '+')
+
+	for i, ch := range label {
+		if i+1 >= w-1 {
+			break
+		}
+		set(y+1, x+1+i, ch)
+	}
+}
+
 func getDisplays() ([]Display, error) {
 	cmd := exec.Command("xrandr", "--query")
 	output, err := cmd.Output()
@@ -362,7 +1247,65 @@ func getDisplays() ([]Display, error) {
 		return nil, fmt.Errorf("failed to run xrandr: %v", err)
 	}
 
-	return parseXrandrOutput(string(output))
+	displays, err := parseXrandrOutput(string(output))
+	if err != nil {
+		return nil, err
+	}
+
+	// Rotation/reflection/scale aren't in --query output, only --verbose;
+	// fetching them is best-effort so a parse failure there doesn't break
+	// basic display listing.
+	if transforms, err := getDisplayTransforms(); err == nil {
+		for i, d := range displays {
+			if t, ok := transforms[d.Name]; ok {
+				displays[i].Rotation = t.Rotation
+				displays[i].Reflection = t.Reflection
+				// The matrix holds xrandr's --scale value; Display.Scale is
+				// the user-facing factor, its reciprocal (0.8 -> 1.25).
+				displays[i].Scale = 1 / t.Scale
+				displays[i].FilterNearest = t.FilterNearest
+			}
+		}
+	}
+
+	computeRelativeTo(displays)
+
+	return displays, nil
+}
+
+// computeRelativeTo fills in each connected display's RelativeTo by matching
+// its PosX/PosY against its neighbors: --query only reports absolute
+// geometry (e.g. "1920x1080+1920+0"), so the relation has to be inferred
+// from which displays' edges or origins line up, same as arandr does when
+// rendering a layout from `xrandr --query` alone.
+func computeRelativeTo(displays []Display) {
+	for i := range displays {
+		d := &displays[i]
+		if !d.Connected {
+			continue
+		}
+
+		for j, o := range displays {
+			if j == i || !o.Connected {
+				continue
+			}
+			switch {
+			case d.PosX == o.PosX && d.PosY == o.PosY:
+				d.RelativeTo = "same-as " + o.Name
+			case d.PosX == o.PosX+o.Current.Width && d.PosY == o.PosY:
+				d.RelativeTo = "right-of " + o.Name
+			case o.PosX == d.PosX+d.Current.Width && d.PosY == o.PosY:
+				d.RelativeTo = "left-of " + o.Name
+			case d.PosY == o.PosY+o.Current.Height && d.PosX == o.PosX:
+				d.RelativeTo = "below " + o.Name
+			case o.PosY == d.PosY+d.Current.Height && d.PosX == o.PosX:
+				d.RelativeTo = "above " + o.Name
+			default:
+				continue
+			}
+			break
+		}
+	}
 }
 
 func parseXrandrOutput(output string) ([]Display, error) {
@@ -371,7 +1314,7 @@ func parseXrandrOutput(output string) ([]Display, error) {
 	var currentDisplay *Display
 
 	// Regex patterns
-	displayRe := regexp.MustCompile(`^([A-Za-z0-9\-]+)\s+(connected|disconnected)`)
+	displayRe := regexp.MustCompile(`^([A-Za-z0-9\-]+)\s+(connected|disconnected)(\s+primary)?(?:\s+\d+x\d+\+(-?\d+)\+(-?\d+))?`)
 	modeLineRe := regexp.MustCompile(`^\s+(\d+)x(\d+)\s+(.+)`)
 
 	for _, line := range lines {
@@ -386,6 +1329,11 @@ func parseXrandrOutput(output string) ([]Display, error) {
 				Name:      matches[1],
 				Connected: matches[2] == "connected",
 				Available: []Mode{},
+				Primary:   strings.TrimSpace(matches[3]) == "primary",
+			}
+			if matches[4] != "" {
+				currentDisplay.PosX, _ = strconv.Atoi(matches[4])
+				currentDisplay.PosY, _ = strconv.Atoi(matches[5])
 			}
 		} else if currentDisplay != nil && currentDisplay.Connected {
 			if matches := modeLineRe.FindStringSubmatch(line); matches != nil {
@@ -429,6 +1377,14 @@ func parseXrandrOutput(output string) ([]Display, error) {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCLI(os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v", err)
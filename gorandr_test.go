@@ -0,0 +1,89 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseModeline(t *testing.T) {
+	cvtOut := `# 1920x1080 59.96 Hz (CVT 2.07M9) hsync: 67.16 kHz; pclk: 173.00 MHz
+Modeline "1920x1080_60.00"  173.00  1920 2048 2248 2576  1080 1083 1088 1120 -hsync +vsync
+`
+	name, params, err := parseModeline(cvtOut)
+	if err != nil {
+		t.Fatalf("parseModeline returned error: %v", err)
+	}
+	if name != "1920x1080_60.00" {
+		t.Errorf("name = %q, want %q", name, "1920x1080_60.00")
+	}
+	want := []string{"173.00", "1920", "2048", "2248", "2576", "1080", "1083", "1088", "1120", "-hsync", "+vsync"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v, want %v", params, want)
+	}
+}
+
+func TestParseModelineNoMatch(t *testing.T) {
+	if _, _, err := parseModeline("no modeline here\n"); err == nil {
+		t.Errorf("expected error for output with no Modeline line")
+	}
+}
+
+func TestResolutionFromModeName(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantW  int
+		wantH  int
+		wantOk bool
+	}{
+		{"1920x1080_60.00", 1920, 1080, true},
+		{"2560x1440_59.95", 2560, 1440, true},
+		{"not-a-mode", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		w, h, ok := resolutionFromModeName(tt.name)
+		if w != tt.wantW || h != tt.wantH || ok != tt.wantOk {
+			t.Errorf("resolutionFromModeName(%q) = (%d, %d, %v), want (%d, %d, %v)",
+				tt.name, w, h, ok, tt.wantW, tt.wantH, tt.wantOk)
+		}
+	}
+}
+
+// TestParseXrandrOutputNegativeOffset covers the common two-monitor layout
+// where a display sits left-of or above another anchored at the origin,
+// which xrandr reports as a negative offset (e.g. "1920x1080+-1920+0").
+func TestParseXrandrOutputNegativeOffset(t *testing.T) {
+	output := `eDP-1 connected primary 1920x1080+0+0 (normal left inverted right x axis y axis) 310mm x 170mm
+   1920x1080     60.00*+  59.93
+HDMI-1 connected 1920x1080+-1920+0 (normal left inverted right x axis y axis) 530mm x 300mm
+   1920x1080     60.00*+  59.93
+`
+	displays, err := parseXrandrOutput(output)
+	if err != nil {
+		t.Fatalf("parseXrandrOutput returned error: %v", err)
+	}
+	if len(displays) != 2 {
+		t.Fatalf("got %d displays, want 2", len(displays))
+	}
+
+	byName := make(map[string]Display, len(displays))
+	for _, d := range displays {
+		byName[d.Name] = d
+	}
+
+	if got := byName["eDP-1"]; got.PosX != 0 || got.PosY != 0 {
+		t.Errorf("eDP-1 pos = (%d, %d), want (0, 0)", got.PosX, got.PosY)
+	}
+	if got := byName["HDMI-1"]; got.PosX != -1920 || got.PosY != 0 {
+		t.Errorf("HDMI-1 pos = (%d, %d), want (-1920, 0)", got.PosX, got.PosY)
+	}
+
+	computeRelativeTo(displays)
+	byName = make(map[string]Display, len(displays))
+	for _, d := range displays {
+		byName[d.Name] = d
+	}
+	if got := byName["HDMI-1"].RelativeTo; got != "left-of eDP-1" {
+		t.Errorf("HDMI-1.RelativeTo = %q, want %q", got, "left-of eDP-1")
+	}
+}
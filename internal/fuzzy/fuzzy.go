@@ -0,0 +1,113 @@
+// Package fuzzy implements a small FZF-style fuzzy matcher: a greedy
+// forward scan finds the earliest in-order occurrence of every pattern
+// rune, then a backward scan from that point finds the tightest start,
+// yielding the smallest window that ends where the forward scan completed.
+// A smaller window that ends later in the candidate is not searched for.
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch reports whether pattern occurs in candidate with its runes in
+// order, and a score where smaller matched windows (tighter, more likely
+// intentional matches) score higher. Matching is case-insensitive unless
+// pattern itself contains an uppercase rune (smart case).
+func FuzzyMatch(pattern, candidate string) (score int, matched bool) {
+	start, end, ok := match(pattern, candidate)
+	if !ok {
+		return 0, false
+	}
+	window := end - start + 1
+	return len([]rune(pattern))*2 - window, true
+}
+
+// MatchedIndices returns the rune indices (into candidate) that make up the
+// tightest match of pattern, for use when highlighting a result. It returns
+// nil if pattern does not match.
+func MatchedIndices(pattern, candidate string) []int {
+	start, end, ok := match(pattern, candidate)
+	if !ok {
+		return nil
+	}
+
+	c := foldCase(pattern, candidate)
+	p := foldCase(pattern, pattern)
+
+	indices := make([]int, 0, len(p))
+	pi := len(p) - 1
+	for ci := end; ci >= start && pi >= 0; ci-- {
+		if c[ci] == p[pi] {
+			indices = append(indices, ci)
+			pi--
+		}
+	}
+	// indices were collected back-to-front; restore candidate order.
+	for i, j := 0, len(indices)-1; i < j; i, j = i+1, j-1 {
+		indices[i], indices[j] = indices[j], indices[i]
+	}
+	return indices
+}
+
+// match runs the forward-then-backward scan and returns the start/end rune
+// indices of the smallest window in candidate that contains pattern in order
+// and ends at the earliest position where the whole pattern has matched.
+func match(pattern, candidate string) (start, end int, ok bool) {
+	if pattern == "" {
+		return 0, -1, true
+	}
+
+	p := foldCase(pattern, pattern)
+	c := foldCase(pattern, candidate)
+
+	// Forward scan: earliest occurrence of each pattern rune, in order.
+	ci := 0
+	end = -1
+	for pi := 0; pi < len(p); pi++ {
+		found := -1
+		for ; ci < len(c); ci++ {
+			if c[ci] == p[pi] {
+				found = ci
+				ci++
+				break
+			}
+		}
+		if found == -1 {
+			return 0, 0, false
+		}
+		end = found
+	}
+
+	// Backward scan from end: pull the start forward as far as possible
+	// while still matching the pattern in reverse, giving the tightest
+	// window for this end position.
+	start = end
+	pi := len(p) - 1
+	for ci := end; ci >= 0 && pi >= 0; ci-- {
+		if c[ci] == p[pi] {
+			start = ci
+			pi--
+		}
+	}
+
+	return start, end, true
+}
+
+// foldCase lowercases candidate for comparison, unless pattern contains an
+// uppercase rune (smart case, as in fzf and vim's 'ignorecase'+'smartcase').
+func foldCase(pattern, candidate string) []rune {
+	if hasUpper(pattern) {
+		return []rune(candidate)
+	}
+	return []rune(strings.ToLower(candidate))
+}
+
+func hasUpper(s string) bool {
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern, candidate string
+		wantMatched        bool
+	}{
+		{"144", "144Hz", true},
+		{"1440", "2560x1440", true},
+		{"144", "2560x1440", true},
+		{"hz144", "144Hz", false},
+		{"", "anything", true},
+		{"zzz", "144Hz", false},
+		{"HD", "hdmi-1", false},
+		{"hd", "HDMI-1", true},
+	}
+
+	for _, tt := range tests {
+		_, matched := FuzzyMatch(tt.pattern, tt.candidate)
+		if matched != tt.wantMatched {
+			t.Errorf("FuzzyMatch(%q, %q) matched = %v, want %v", tt.pattern, tt.candidate, matched, tt.wantMatched)
+		}
+	}
+}
+
+func TestFuzzyMatchScoresTighterWindowHigher(t *testing.T) {
+	// "144" occurs as a tight run in "144Hz" but is spread out in
+	// "1x4x4xHz"; the tighter match should score higher.
+	tight, ok := FuzzyMatch("144", "144Hz")
+	if !ok {
+		t.Fatalf("expected tight match to succeed")
+	}
+	loose, ok := FuzzyMatch("144", "1x4x4xHz")
+	if !ok {
+		t.Fatalf("expected loose match to succeed")
+	}
+	if tight <= loose {
+		t.Errorf("expected tight match score %d to be greater than loose match score %d", tight, loose)
+	}
+}
+
+func TestMatchedIndices(t *testing.T) {
+	tests := []struct {
+		pattern, candidate string
+		want               []int
+	}{
+		{"144", "144Hz", []int{0, 1, 2}},
+		{"1440", "2560x1440", []int{5, 6, 7, 8}},
+		{"zzz", "144Hz", nil},
+	}
+
+	for _, tt := range tests {
+		got := MatchedIndices(tt.pattern, tt.candidate)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MatchedIndices(%q, %q) = %v, want %v", tt.pattern, tt.candidate, got, tt.want)
+		}
+	}
+
+	if got := MatchedIndices("", "144Hz"); len(got) != 0 {
+		t.Errorf("MatchedIndices(\"\", ...) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ProfileDisplay is the persisted configuration for a single display within
+// a Profile: its mode, position, and primary flag, keyed by EDID hash so it
+// survives the output being reattached to a different port.
+type ProfileDisplay struct {
+	Name       string  `json:"name"`
+	EDID       string  `json:"edid"`
+	Width      int     `json:"width"`
+	Height     int     `json:"height"`
+	Rate       float64 `json:"rate"`
+	PosX       int     `json:"pos_x"`
+	PosY       int     `json:"pos_y"`
+	Primary    bool    `json:"primary"`
+	Rotation   string  `json:"rotation"`
+	Reflection string  `json:"reflection"`
+	Scale      float64 `json:"scale"`
+}
+
+// Profile is a named, full multi-display configuration saved under
+// $XDG_CONFIG_HOME/gorandr/profiles/. Fingerprint is the hash of the sorted
+// EDIDs of every display present when the profile was saved, used by the
+// daemon to recognize this exact set of displays on reconnect.
+type Profile struct {
+	Name        string           `json:"name"`
+	Fingerprint string           `json:"fingerprint"`
+	Displays    []ProfileDisplay `json:"displays"`
+}
+
+// buildProfile captures the current configuration of every connected
+// display into a Profile ready to be saved.
+func buildProfile(name string, displays []Display, edids map[string]string) Profile {
+	p := Profile{Name: name}
+
+	var fingerprints []string
+	for _, d := range displays {
+		if !d.Connected {
+			continue
+		}
+		edid := edids[d.Name]
+		fingerprints = append(fingerprints, edid)
+		tr := d.transform()
+		p.Displays = append(p.Displays, ProfileDisplay{
+			Name:       d.Name,
+			EDID:       edid,
+			Width:      d.Current.Width,
+			Height:     d.Current.Height,
+			Rate:       d.Current.Rate,
+			PosX:       d.PosX,
+			PosY:       d.PosY,
+			Primary:    d.Primary,
+			Rotation:   tr.Rotation,
+			Reflection: tr.Reflection,
+			Scale:      tr.Scale,
+		})
+	}
+	p.Fingerprint = fingerprintEDIDs(fingerprints)
+
+	return p
+}
+
+// fingerprintEDIDs hashes a sorted set of EDID hashes so the same set of
+// displays always produces the same fingerprint regardless of port order.
+func fingerprintEDIDs(edids []string) string {
+	sorted := append([]string(nil), edids...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// profilesDir returns $XDG_CONFIG_HOME/gorandr/profiles (falling back to
+// ~/.config), creating it if necessary.
+func profilesDir() (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve home directory: %v", err)
+		}
+		base = filepath.Join(home, ".config")
+	}
+
+	dir := filepath.Join(base, "gorandr", "profiles")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create profiles directory: %v", err)
+	}
+	return dir, nil
+}
+
+// profilePath joins dir and name+".json", rejecting any name that isn't a
+// single path element (e.g. containing "/" or "..") so a profile name typed
+// into the TUI or passed on argv can't escape the profiles directory.
+func profilePath(dir, name string) (string, error) {
+	base := filepath.Base(name)
+	if base != name || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid profile name %q", name)
+	}
+	return filepath.Join(dir, base+".json"), nil
+}
+
+func saveProfile(p Profile) error {
+	dir, err := profilesDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode profile: %v", err)
+	}
+
+	path, err := profilePath(dir, p.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write profile: %v", err)
+	}
+	return nil
+}
+
+func loadProfile(name string) (*Profile, error) {
+	dir, err := profilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := profilePath(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile %q: %v", name, err)
+	}
+
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse profile %q: %v", name, err)
+	}
+	return &p, nil
+}
+
+func listProfiles() ([]string, error) {
+	dir, err := profilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %v", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func deleteProfile(name string) error {
+	dir, err := profilesDir()
+	if err != nil {
+		return err
+	}
+	path, err := profilePath(dir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to delete profile %q: %v", name, err)
+	}
+	return nil
+}
+
+// applyProfileArgs composes the xrandr arguments for every output in a
+// profile, mirroring the batched invocation model.applyMode builds for the
+// interactive wizard. Each ProfileDisplay's EDID is resolved against
+// liveEDIDs to find whatever output currently reports that EDID, so a
+// profile still applies correctly after the monitor moves to a different
+// port; a display whose EDID can no longer be found is skipped.
+func applyProfileArgs(p Profile, liveEDIDs map[string]string) []string {
+	edidToOutput := make(map[string]string, len(liveEDIDs))
+	for name, edid := range liveEDIDs {
+		edidToOutput[edid] = name
+	}
+
+	var args []string
+	for _, d := range p.Displays {
+		output := d.Name
+		if d.EDID != "" {
+			if live, ok := edidToOutput[d.EDID]; ok {
+				output = live
+			} else {
+				continue
+			}
+		}
+
+		args = append(args, "--output", output)
+		args = append(args, "--mode", fmt.Sprintf("%dx%d", d.Width, d.Height))
+		args = append(args, "--rate", fmt.Sprintf("%.1f", d.Rate))
+		if d.PosX != 0 || d.PosY != 0 {
+			args = append(args, "--pos", fmt.Sprintf("%d+%d", d.PosX, d.PosY))
+		}
+		if d.Primary {
+			args = append(args, "--primary")
+		}
+		args = append(args, (transform{Rotation: d.Rotation, Reflection: d.Reflection, Scale: d.Scale}).args()...)
+	}
+	return args
+}
+
+func applyProfile(p Profile) error {
+	liveEDIDs, err := getDisplayEDIDs()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("xrandr", applyProfileArgs(p, liveEDIDs)...)
+	return cmd.Run()
+}
+
+// getDisplayEDIDs runs `xrandr --props` and returns a sha256 hash of each
+// connected output's EDID, keyed by output name.
+func getDisplayEDIDs() (map[string]string, error) {
+	cmd := exec.Command("xrandr", "--props")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run xrandr --props: %v", err)
+	}
+	return parseEDIDs(string(output)), nil
+}
+
+func parseEDIDs(output string) map[string]string {
+	edids := make(map[string]string)
+
+	outputRe := regexp.MustCompile(`^([A-Za-z0-9\-]+)\s+(connected|disconnected)`)
+	hexLineRe := regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+	var current string
+	var edidHex strings.Builder
+	inEDID := false
+
+	flush := func() {
+		if current != "" && edidHex.Len() > 0 {
+			sum := sha256.Sum256([]byte(edidHex.String()))
+			edids[current] = hex.EncodeToString(sum[:])
+		}
+		edidHex.Reset()
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if matches := outputRe.FindStringSubmatch(line); matches != nil {
+			flush()
+			current = matches[1]
+			inEDID = false
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "EDID:" {
+			inEDID = true
+			continue
+		}
+		if inEDID {
+			if hexLineRe.MatchString(trimmed) {
+				edidHex.WriteString(trimmed)
+				continue
+			}
+			inEDID = false
+		}
+	}
+	flush()
+
+	return edids
+}
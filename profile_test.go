@@ -0,0 +1,59 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestParseEDIDs(t *testing.T) {
+	output := `eDP-1 connected primary 1920x1080+0+0 (normal left inverted right x axis y axis) 310mm x 170mm
+	EDID:
+		00ffffffffffff00
+		0010203040506070
+	1920x1080 (0x45) 141.000MHz
+HDMI-1 disconnected (normal left inverted right x axis y axis)
+`
+	got := parseEDIDs(output)
+
+	wantSum := sha256.Sum256([]byte("00ffffffffffff000010203040506070"))
+	want := hex.EncodeToString(wantSum[:])
+
+	if got["eDP-1"] != want {
+		t.Errorf("parseEDIDs()[\"eDP-1\"] = %q, want %q", got["eDP-1"], want)
+	}
+	if _, ok := got["HDMI-1"]; ok {
+		t.Errorf("expected no EDID entry for disconnected HDMI-1, got %q", got["HDMI-1"])
+	}
+}
+
+func TestParseEDIDsNoEDID(t *testing.T) {
+	output := `HDMI-1 disconnected (normal left inverted right x axis y axis)
+`
+	got := parseEDIDs(output)
+	if len(got) != 0 {
+		t.Errorf("expected no EDIDs, got %v", got)
+	}
+}
+
+func TestProfilePathRejectsTraversal(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"home-setup", false},
+		{"my profile", false},
+		{"../../../etc/passwd", true},
+		{"..", true},
+		{".", true},
+		{"sub/dir", true},
+		{"/etc/passwd", true},
+	}
+
+	for _, tt := range tests {
+		_, err := profilePath("/tmp/gorandr-profiles", tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("profilePath(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// displayTransform is the rotation/reflection/scale/filter xrandr currently
+// has applied to an output, as derived from its Transform matrix and the
+// "filter:" line that follows it.
+type displayTransform struct {
+	Rotation      string
+	Reflection    string
+	Scale         float64
+	FilterNearest bool
+}
+
+// getDisplayTransforms runs `xrandr --verbose` and returns each connected
+// output's current rotation/reflection/scale, keyed by output name.
+func getDisplayTransforms() (map[string]displayTransform, error) {
+	output, err := exec.Command("xrandr", "--verbose").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run xrandr --verbose: %v", err)
+	}
+	return parseTransforms(string(output)), nil
+}
+
+var (
+	verboseOutputRe = regexp.MustCompile(`^([A-Za-z0-9\-]+)\s+(connected|disconnected)`)
+	transformLineRe = regexp.MustCompile(`Transform:\s*([\d.eE+-]+)\s+([\d.eE+-]+)\s+([\d.eE+-]+)\s*/\s*([\d.eE+-]+)\s+([\d.eE+-]+)\s+([\d.eE+-]+)\s*/\s*([\d.eE+-]+)\s+([\d.eE+-]+)\s+([\d.eE+-]+)`)
+	filterLineRe    = regexp.MustCompile(`filter:\s*(\w+)`)
+)
+
+func parseTransforms(output string) map[string]displayTransform {
+	transforms := make(map[string]displayTransform)
+	var current string
+
+	for _, line := range strings.Split(output, "\n") {
+		if matches := verboseOutputRe.FindStringSubmatch(line); matches != nil {
+			current = matches[1]
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		if matches := transformLineRe.FindStringSubmatch(line); matches != nil {
+			var m [9]float64
+			for i := range m {
+				m[i], _ = strconv.ParseFloat(matches[i+1], 64)
+			}
+			transforms[current] = matrixToTransform(m)
+			continue
+		}
+		// The filter line (e.g. "filter: nearest") follows the Transform
+		// matrix for the same output, so the entry it belongs to is
+		// already present in transforms.
+		if matches := filterLineRe.FindStringSubmatch(line); matches != nil {
+			if t, ok := transforms[current]; ok {
+				t.FilterNearest = strings.EqualFold(matches[1], "nearest")
+				transforms[current] = t
+			}
+		}
+	}
+
+	return transforms
+}
+
+// matrixToTransform derives rotation, reflection, and scale from an xrandr
+// Transform matrix [a b c / d e f / g h i]. Rotation is read off the
+// off-diagonal terms, reflection from a negative-only diagonal, and scale
+// from the diagonal magnitude when the transform is a pure scale (no
+// rotation component).
+func matrixToTransform(m [9]float64) displayTransform {
+	a, b, d, e := m[0], m[1], m[3], m[4]
+
+	t := displayTransform{Rotation: "normal", Reflection: "none", Scale: 1.0}
+
+	switch {
+	case b < 0 && d > 0:
+		t.Rotation = "left"
+	case b > 0 && d < 0:
+		t.Rotation = "right"
+	case a < 0 && e < 0:
+		t.Rotation = "inverted"
+	case a < 0:
+		t.Reflection = "x"
+	case e < 0:
+		t.Reflection = "y"
+	}
+
+	if b == 0 && d == 0 && a != 0 {
+		scale := a
+		if scale < 0 {
+			scale = -scale
+		}
+		t.Scale = scale
+	}
+
+	return t
+}
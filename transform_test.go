@@ -0,0 +1,75 @@
+package main
+
+import "testing"
+
+func TestMatrixToTransform(t *testing.T) {
+	tests := []struct {
+		name           string
+		m              [9]float64
+		wantRotation   string
+		wantReflection string
+		wantScale      float64
+	}{
+		{"identity", [9]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}, "normal", "none", 1.0},
+		{"left", [9]float64{0, -1, 0, 1, 0, 0, 0, 0, 1}, "left", "none", 1.0},
+		{"right", [9]float64{0, 1, 0, -1, 0, 0, 0, 0, 1}, "right", "none", 1.0},
+		{"inverted", [9]float64{-1, 0, 0, 0, -1, 0, 0, 0, 1}, "inverted", "none", 1.0},
+		{"reflect-x", [9]float64{-1, 0, 0, 0, 1, 0, 0, 0, 1}, "normal", "x", 1.0},
+		{"reflect-y", [9]float64{1, 0, 0, 0, -1, 0, 0, 0, 1}, "normal", "y", 1.0},
+		{"scale-0.8", [9]float64{0.8, 0, 0, 0, 0.8, 0, 0, 0, 1}, "normal", "none", 0.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matrixToTransform(tt.m)
+			if got.Rotation != tt.wantRotation || got.Reflection != tt.wantReflection || got.Scale != tt.wantScale {
+				t.Errorf("matrixToTransform(%v) = %+v, want {Rotation: %q, Reflection: %q, Scale: %v}",
+					tt.m, got, tt.wantRotation, tt.wantReflection, tt.wantScale)
+			}
+		})
+	}
+}
+
+func TestParseTransforms(t *testing.T) {
+	output := `eDP-1 connected primary 1920x1080+0+0 left (normal left inverted right x axis y axis) 310mm x 170mm
+	Transform: 1.000000 0.000000 0.000000 / 0.000000 1.000000 0.000000 / 0.000000 0.000000 1.000000
+	           filter: bilinear
+  1920x1080 (0x45) 141.000MHz *current
+HDMI-1 disconnected (normal left inverted right x axis y axis)
+`
+	got := parseTransforms(output)
+
+	eDP, ok := got["eDP-1"]
+	if !ok {
+		t.Fatalf("expected a transform for eDP-1")
+	}
+	if eDP.Rotation != "normal" || eDP.Reflection != "none" || eDP.Scale != 1.0 || eDP.FilterNearest {
+		t.Errorf("eDP-1 transform = %+v, want identity with bilinear filter", eDP)
+	}
+	if _, ok := got["HDMI-1"]; ok {
+		t.Errorf("expected no transform parsed for disconnected HDMI-1")
+	}
+}
+
+// TestParseTransformsNearestFilter covers the scaled-display case this
+// parses for: a display with a fractional scale and "--filter nearest" set,
+// whose filter choice must be preserved across later applies.
+func TestParseTransformsNearestFilter(t *testing.T) {
+	output := `HDMI-1 connected 2560x1440+0+0 (normal left inverted right x axis y axis) 600mm x 340mm
+	Transform: 0.800000 0.000000 0.000000 / 0.000000 0.800000 0.000000 / 0.000000 0.000000 1.000000
+	           filter: nearest
+  2560x1440 (0x46) 143.912MHz *current
+`
+	got := parseTransforms(output)
+
+	hdmi, ok := got["HDMI-1"]
+	if !ok {
+		t.Fatalf("expected a transform for HDMI-1")
+	}
+	if !hdmi.FilterNearest {
+		t.Errorf("HDMI-1.FilterNearest = false, want true")
+	}
+	if hdmi.Scale != 0.8 {
+		t.Errorf("HDMI-1.Scale = %v, want 0.8", hdmi.Scale)
+	}
+}